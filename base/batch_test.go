@@ -0,0 +1,78 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBatchClientExecute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Requests []BatchRequestItem `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("could not decode batch request: %v", err)
+		}
+
+		// Respond in reverse order to the requests, to exercise that results are keyed by Id
+		// rather than by position.
+		resp := struct {
+			Responses []BatchResponseItem `json:"responses"`
+		}{}
+		for i := len(req.Requests) - 1; i >= 0; i-- {
+			resp.Responses = append(resp.Responses, BatchResponseItem{
+				Id:     req.Requests[i].Id,
+				Status: http.StatusNoContent,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatalf("could not encode batch response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Version10, "tenant-id")
+	client.Endpoint = server.URL
+	batchClient := NewBatchClient(client)
+
+	requests := []BatchRequestItem{
+		{Id: "0", Method: http.MethodDelete, Url: "/groups/a/members/x/$ref"},
+		{Id: "1", Method: http.MethodDelete, Url: "/groups/a/members/y/$ref"},
+	}
+
+	results, status, err := batchClient.Execute(context.Background(), requests)
+	if err != nil {
+		t.Fatalf("Execute() returned error: %v", err)
+	}
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+	for _, id := range []string{"0", "1"} {
+		response, ok := results[id]
+		if !ok {
+			t.Fatalf("no response found for request id %s", id)
+		}
+		if response.Status != http.StatusNoContent {
+			t.Errorf("response %s status = %d, want %d", id, response.Status, http.StatusNoContent)
+		}
+	}
+}
+
+func TestBatchClientExecuteTooManyRequests(t *testing.T) {
+	client := NewClient(Version10, "tenant-id")
+	batchClient := NewBatchClient(client)
+
+	requests := make([]BatchRequestItem, MaxBatchRequests+1)
+	for i := range requests {
+		requests[i] = BatchRequestItem{Id: string(rune('a' + i)), Method: http.MethodGet, Url: "/groups"}
+	}
+
+	if _, _, err := batchClient.Execute(context.Background(), requests); err == nil {
+		t.Fatal("Execute() with more than MaxBatchRequests sub-requests should have returned an error")
+	}
+}
@@ -0,0 +1,248 @@
+package base
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// ApiVersion is the Microsoft Graph API version to target.
+type ApiVersion string
+
+const (
+	Version10   ApiVersion = "v1.0"
+	VersionBeta ApiVersion = "beta"
+)
+
+// DefaultEndpoint is the base URL for the Microsoft Graph API.
+const DefaultEndpoint = "https://graph.microsoft.com"
+
+// DefaultMaxRetries is the number of times a request is retried when Graph responds with a
+// throttling (429) or transient (503) status, if Client.MaxRetries is unset.
+const DefaultMaxRetries = 5
+
+// DefaultRetryDelay is used as the backoff between retries when Graph's response carries no
+// Retry-After header.
+const DefaultRetryDelay = 1 * time.Second
+
+// Client is a base client for interacting with Microsoft Graph.
+type Client struct {
+	Endpoint   string
+	ApiVersion ApiVersion
+	TenantId   string
+
+	// MaxRetries is the number of times to retry a request that receives a 429 or 503
+	// response before giving up. Zero means DefaultMaxRetries.
+	MaxRetries int
+
+	httpClient *http.Client
+}
+
+// NewClient returns a new base Client configured for the given API version and tenant.
+func NewClient(apiVersion ApiVersion, tenantId string) Client {
+	return Client{
+		Endpoint:   DefaultEndpoint,
+		ApiVersion: apiVersion,
+		TenantId:   tenantId,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// GetHttpRequestInput configures a GET request.
+type GetHttpRequestInput struct {
+	ValidStatusCodes []int
+	Uri              Uri
+
+	// ODataQuery carries OData query options (e.g. $select, $top, $search) to be applied to
+	// the request. When set, any option that requires the ConsistencyLevel: eventual header
+	// (such as $search or $count) is handled automatically.
+	ODataQuery *ODataQuery
+}
+
+// PostHttpRequestInput configures a POST request.
+type PostHttpRequestInput struct {
+	Body             []byte
+	ValidStatusCodes []int
+	Uri              Uri
+}
+
+// PatchHttpRequestInput configures a PATCH request.
+type PatchHttpRequestInput struct {
+	Body             []byte
+	ValidStatusCodes []int
+	Uri              Uri
+}
+
+// DeleteHttpRequestInput configures a DELETE request.
+type DeleteHttpRequestInput struct {
+	ValidStatusCodes []int
+	Uri              Uri
+}
+
+// performRequest sends req and validates the response status, retrying on 429 and 503
+// responses (honoring Retry-After) up to Client.MaxRetries times. It returns the response, the
+// HTTP status code, a structured OData error when Graph returned one, and a non-nil error for
+// any failure, including an unexpected status code.
+func (c Client) performRequest(req *http.Request, validStatusCodes []int) (*http.Response, int, *odata.Error, error) {
+	maxRetries := c.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultMaxRetries
+	}
+
+	var status int
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, status, nil, fmt.Errorf("base: request failed: %v", err)
+		}
+		status = resp.StatusCode
+
+		if (status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable) && attempt < maxRetries {
+			wait := retryAfter(resp.Header.Get("Retry-After"))
+			resp.Body.Close()
+
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, status, nil, fmt.Errorf("base: could not rewind request body for retry: %v", err)
+				}
+				req.Body = body
+			}
+
+			select {
+			case <-time.After(wait):
+				continue
+			case <-req.Context().Done():
+				return nil, status, nil, req.Context().Err()
+			}
+		}
+
+		if !statusCodeValid(status, validStatusCodes) {
+			defer resp.Body.Close()
+			body, _ := ioutil.ReadAll(resp.Body)
+			oDataErr := odata.Parse(body)
+			if oDataErr != nil {
+				return resp, status, oDataErr, fmt.Errorf("base: unexpected status %d: %s", status, oDataErr.Error())
+			}
+			return resp, status, nil, fmt.Errorf("base: unexpected status %d: %s", status, string(body))
+		}
+
+		return resp, status, nil, nil
+	}
+}
+
+// statusCodeValid reports whether status appears in validStatusCodes. An empty
+// validStatusCodes accepts any status.
+func statusCodeValid(status int, validStatusCodes []int) bool {
+	if len(validStatusCodes) == 0 {
+		return true
+	}
+	for _, s := range validStatusCodes {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
+
+// retryAfter parses a Retry-After header value expressed in seconds, falling back to
+// DefaultRetryDelay if the header is absent or malformed. Graph does not use the HTTP-date
+// form of Retry-After, so only the delay-seconds form is supported.
+func retryAfter(header string) time.Duration {
+	if header == "" {
+		return DefaultRetryDelay
+	}
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return DefaultRetryDelay
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// Get performs a GET request against the Graph API.
+func (c Client) Get(ctx context.Context, input GetHttpRequestInput) (*http.Response, int, *odata.Error, error) {
+	target, err := c.uri(input.Uri)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	if input.ODataQuery != nil {
+		params := target.Query()
+		for key, values := range input.ODataQuery.Values() {
+			for _, value := range values {
+				params.Add(key, value)
+			}
+		}
+		target.RawQuery = params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("base: could not build request: %v", err)
+	}
+
+	if input.ODataQuery != nil && input.ODataQuery.needsConsistencyLevel() {
+		req.Header.Set("ConsistencyLevel", "eventual")
+	}
+
+	return c.performRequest(req, input.ValidStatusCodes)
+}
+
+// Post performs a POST request against the Graph API.
+func (c Client) Post(ctx context.Context, input PostHttpRequestInput) (*http.Response, int, *odata.Error, error) {
+	target, err := c.uri(input.Uri)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.String(), bytes.NewBuffer(input.Body))
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("base: could not build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.performRequest(req, input.ValidStatusCodes)
+}
+
+// Patch performs a PATCH request against the Graph API.
+func (c Client) Patch(ctx context.Context, input PatchHttpRequestInput) (*http.Response, int, *odata.Error, error) {
+	target, err := c.uri(input.Uri)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, target.String(), bytes.NewBuffer(input.Body))
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("base: could not build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.performRequest(req, input.ValidStatusCodes)
+}
+
+// Delete performs a DELETE request against the Graph API.
+func (c Client) Delete(ctx context.Context, input DeleteHttpRequestInput) (*http.Response, int, *odata.Error, error) {
+	target, err := c.uri(input.Uri)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, target.String(), nil)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("base: could not build request: %v", err)
+	}
+	return c.performRequest(req, input.ValidStatusCodes)
+}
+
+// GetAbsolute performs a GET request against an absolute URL, such as an @odata.nextLink
+// or @odata.deltaLink returned by a previous response. This is used to follow server-side
+// continuation links without having to reconstruct them via Uri.
+func (c Client) GetAbsolute(ctx context.Context, url string, validStatusCodes []int) (*http.Response, int, *odata.Error, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("base: could not build request: %v", err)
+	}
+	return c.performRequest(req, validStatusCodes)
+}
@@ -0,0 +1,133 @@
+package base
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryAfter(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{"empty header falls back to default", "", DefaultRetryDelay},
+		{"valid seconds", "5", 5 * time.Second},
+		{"zero seconds", "0", 0},
+		{"malformed value falls back to default", "not-a-number", DefaultRetryDelay},
+		{"negative value falls back to default", "-1", DefaultRetryDelay},
+		{"http-date form is unsupported and falls back to default", "Wed, 21 Oct 2026 07:28:00 GMT", DefaultRetryDelay},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := retryAfter(c.header); got != c.want {
+				t.Errorf("retryAfter(%q) = %v, want %v", c.header, got, c.want)
+			}
+		})
+	}
+}
+
+func TestClientPerformRequestRetriesOnThrottlingAndResendsBody(t *testing.T) {
+	var attempts int32
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(Version10, "tenant-id")
+	client.Endpoint = server.URL
+
+	start := time.Now()
+	_, status, oDataErr, err := client.Post(context.Background(), PostHttpRequestInput{
+		Body:             []byte(`{"hello":"world"}`),
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri:              Uri{Entity: "/groups"},
+	})
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("Post() returned error: %v", err)
+	}
+	if oDataErr != nil {
+		t.Errorf("oDataErr = %v, want nil", oDataErr)
+	}
+	if status != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", status, http.StatusNoContent)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Fatalf("server received %d requests, want 2 (one throttled, one retried)", got)
+	}
+	if elapsed < time.Second {
+		t.Errorf("elapsed time = %v, want at least the 1s Retry-After delay to have been honored", elapsed)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[0] != `{"hello":"world"}` {
+		t.Errorf("request bodies across attempts = %#v, want identical %q on both", bodies, `{"hello":"world"}`)
+	}
+}
+
+func TestClientPerformRequestGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(Version10, "tenant-id")
+	client.Endpoint = server.URL
+	client.MaxRetries = 2
+
+	_, status, _, err := client.Post(context.Background(), PostHttpRequestInput{
+		Body:             []byte(`{}`),
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri:              Uri{Entity: "/groups"},
+	})
+	if err == nil {
+		t.Fatal("expected an error after exhausting retries, got nil")
+	}
+	if status != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", status, http.StatusServiceUnavailable)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("server received %d requests, want 3 (1 initial + 2 retries)", got)
+	}
+}
+
+func TestClientPerformRequestRespectsContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(Version10, "tenant-id")
+	client.Endpoint = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, _, _, err := client.Post(ctx, PostHttpRequestInput{
+		Body:             []byte(`{}`),
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri:              Uri{Entity: "/groups"},
+	}); err == nil {
+		t.Fatal("expected an error when the context is cancelled mid-retry-wait, got nil")
+	}
+}
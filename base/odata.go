@@ -0,0 +1,74 @@
+package base
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ODataQuery describes the OData query options supported by Microsoft Graph for read
+// operations. Entity clients accept an ODataQuery so that callers can shape the request
+// without needing to know the underlying query parameter names.
+type ODataQuery struct {
+	// Filter restricts the result set. Corresponds to $filter.
+	Filter string
+
+	// Select limits the properties returned for each resource. Corresponds to $select.
+	Select []string
+
+	// Top limits the number of results returned in a single page. Corresponds to $top.
+	Top int
+
+	// Skip skips a number of results before returning the rest. Corresponds to $skip.
+	Skip int
+
+	// OrderBy orders the result set by one or more properties. Corresponds to $orderby.
+	OrderBy string
+
+	// Search restricts results to those matching a search expression. Corresponds to $search.
+	// Using Search requires the ConsistencyLevel: eventual header, which is added automatically.
+	Search string
+
+	// Expand includes related resources inline. Corresponds to $expand.
+	Expand string
+
+	// Count requests a total count of matching results. Corresponds to $count.
+	// Using Count requires the ConsistencyLevel: eventual header, which is added automatically.
+	Count bool
+}
+
+// Values returns the query parameters represented by this ODataQuery.
+func (q ODataQuery) Values() url.Values {
+	params := url.Values{}
+	if q.Filter != "" {
+		params.Add("$filter", q.Filter)
+	}
+	if len(q.Select) > 0 {
+		params.Add("$select", strings.Join(q.Select, ","))
+	}
+	if q.Top > 0 {
+		params.Add("$top", strconv.Itoa(q.Top))
+	}
+	if q.Skip > 0 {
+		params.Add("$skip", strconv.Itoa(q.Skip))
+	}
+	if q.OrderBy != "" {
+		params.Add("$orderby", q.OrderBy)
+	}
+	if q.Search != "" {
+		params.Add("$search", q.Search)
+	}
+	if q.Expand != "" {
+		params.Add("$expand", q.Expand)
+	}
+	if q.Count {
+		params.Add("$count", "true")
+	}
+	return params
+}
+
+// needsConsistencyLevel reports whether this query requires the ConsistencyLevel: eventual
+// header, which Graph mandates for $search and advanced use of $count.
+func (q ODataQuery) needsConsistencyLevel() bool {
+	return q.Search != "" || q.Count
+}
@@ -0,0 +1,70 @@
+package base
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/manicminer/hamilton/odata"
+)
+
+// PageFunc is invoked once per page retrieved during pagination. It receives the raw response
+// body for that page and returns the @odata.nextLink carried in it, if any, and whether
+// pagination should continue. Decoding the page and accumulating results into the caller's own
+// state both happen inside fn.
+type PageFunc func(page []byte) (nextLink string, cont bool, err error)
+
+// GetPages performs an initial GET request described by input, then follows @odata.nextLink,
+// invoking fn once per page until fn returns cont=false or the final page (no nextLink) has
+// been processed. Each page's response body is read and closed before the next page is
+// fetched, so at most one response body is ever open at a time regardless of how many pages
+// the result set spans. If any page's request returns a non-2xx response, the parsed
+// *odata.Error is returned alongside the plain error.
+func (c Client) GetPages(ctx context.Context, input GetHttpRequestInput, fn PageFunc) (int, *odata.Error, error) {
+	resp, status, oDataErr, err := c.Get(ctx, input)
+	if err != nil {
+		return status, oDataErr, err
+	}
+	return c.followPages(ctx, resp, status, input.ValidStatusCodes, fn)
+}
+
+// GetPagesFrom behaves like GetPages, except that if startLink is non-empty it is treated as
+// an absolute continuation link (such as a previously captured @odata.deltaLink) and is
+// fetched directly instead of issuing a fresh request built from input.
+func (c Client) GetPagesFrom(ctx context.Context, startLink string, input GetHttpRequestInput, fn PageFunc) (int, *odata.Error, error) {
+	if startLink == "" {
+		return c.GetPages(ctx, input, fn)
+	}
+	resp, status, oDataErr, err := c.GetAbsolute(ctx, startLink, input.ValidStatusCodes)
+	if err != nil {
+		return status, oDataErr, err
+	}
+	return c.followPages(ctx, resp, status, input.ValidStatusCodes, fn)
+}
+
+func (c Client) followPages(ctx context.Context, resp *http.Response, status int, validStatusCodes []int, fn PageFunc) (int, *odata.Error, error) {
+	for {
+		body, readErr := ioutil.ReadAll(resp.Body)
+		closeErr := resp.Body.Close()
+		if readErr != nil {
+			return status, nil, readErr
+		}
+		if closeErr != nil {
+			return status, nil, closeErr
+		}
+
+		nextLink, cont, err := fn(body)
+		if err != nil {
+			return status, nil, err
+		}
+		if !cont || nextLink == "" {
+			return status, nil, nil
+		}
+
+		var oDataErr *odata.Error
+		resp, status, oDataErr, err = c.GetAbsolute(ctx, nextLink, validStatusCodes)
+		if err != nil {
+			return status, oDataErr, err
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// closeTrackingTransport wraps every response body in a closeTrackingBody, so a test can assert
+// how many times a page's body was closed.
+type closeTrackingTransport struct {
+	base   http.RoundTripper
+	closes *int32
+}
+
+func (t *closeTrackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	resp.Body = closeTrackingBody{ReadCloser: resp.Body, closes: t.closes}
+	return resp, nil
+}
+
+type closeTrackingBody struct {
+	io.ReadCloser
+	closes *int32
+}
+
+func (b closeTrackingBody) Close() error {
+	atomic.AddInt32(b.closes, 1)
+	return b.ReadCloser.Close()
+}
+
+func TestClientGetPagesClosesEachPageAndAggregatesResults(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1.0/groups":
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"value":[{"id":"1"}],"@odata.nextLink":"%s/v1.0/groups/page2"}`, server.URL)))
+		case "/v1.0/groups/page2":
+			_, _ = w.Write([]byte(`{"value":[{"id":"2"}]}`))
+		default:
+			t.Fatalf("unexpected request path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var closes int32
+	client := NewClient(Version10, "tenant-id")
+	client.Endpoint = server.URL
+	client.httpClient = &http.Client{Transport: &closeTrackingTransport{base: http.DefaultTransport, closes: &closes}}
+
+	var pages [][]byte
+	status, oDataErr, err := client.GetPages(context.Background(), GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri:              Uri{Entity: "/groups"},
+	}, func(page []byte) (string, bool, error) {
+		pages = append(pages, page)
+		var data struct {
+			NextLink string `json:"@odata.nextLink"`
+		}
+		if err := json.Unmarshal(page, &data); err != nil {
+			return "", false, err
+		}
+		return data.NextLink, true, nil
+	})
+	if err != nil {
+		t.Fatalf("GetPages() returned error: %v", err)
+	}
+	if oDataErr != nil {
+		t.Errorf("oDataErr = %v, want nil", oDataErr)
+	}
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("callback invoked for %d pages, want 2", len(pages))
+	}
+	if got := atomic.LoadInt32(&closes); got != 2 {
+		t.Errorf("response bodies closed %d times, want 2 (one per page, before the next page is fetched)", got)
+	}
+}
@@ -0,0 +1,52 @@
+package base
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Uri describes a Graph resource to be requested.
+type Uri struct {
+	Entity      string
+	Params      url.Values
+	HasTenantId bool
+}
+
+// path returns the path portion of the given Uri, relative to the host: the API version, the
+// tenant ID when HasTenantId is set, and the entity path, with no host prepended.
+// Both uri and RelativeUri build on this so that a direct request and a $batch sub-request
+// addressing the same Uri always resolve to the same path.
+func (c Client) path(u Uri) string {
+	path := fmt.Sprintf("/%s", c.ApiVersion)
+	if u.HasTenantId {
+		path = fmt.Sprintf("%s/%s", path, c.TenantId)
+	}
+	return fmt.Sprintf("%s%s", path, u.Entity)
+}
+
+// uri returns the full URL for the given Uri, relative to the supplied Client.
+func (c Client) uri(u Uri) (*url.URL, error) {
+	target, err := url.Parse(strings.TrimSuffix(c.Endpoint, "/") + c.path(u))
+	if err != nil {
+		return nil, fmt.Errorf("base: could not parse uri: %v", err)
+	}
+	if u.Params != nil {
+		target.RawQuery = u.Params.Encode()
+	}
+	return target, nil
+}
+
+// RelativeUri returns the path and query for the given Uri, exactly as uri would resolve them,
+// but without the scheme and host. It is exported so that callers building $batch sub-requests
+// can address the same tenant-scoped path as the equivalent direct request.
+func (c Client) RelativeUri(u Uri) (string, error) {
+	target, err := c.uri(u)
+	if err != nil {
+		return "", err
+	}
+	if target.RawQuery == "" {
+		return target.Path, nil
+	}
+	return fmt.Sprintf("%s?%s", target.Path, target.RawQuery), nil
+}
@@ -0,0 +1,33 @@
+package base
+
+import "testing"
+
+func TestClientRelativeUri(t *testing.T) {
+	client := NewClient(Version10, "11111111-1111-1111-1111-111111111111")
+
+	got, err := client.RelativeUri(Uri{
+		Entity:      "/groups/22222222-2222-2222-2222-222222222222/members/33333333-3333-3333-3333-333333333333/$ref",
+		HasTenantId: true,
+	})
+	if err != nil {
+		t.Fatalf("RelativeUri() returned error: %v", err)
+	}
+
+	want := "/v1.0/11111111-1111-1111-1111-111111111111/groups/22222222-2222-2222-2222-222222222222/members/33333333-3333-3333-3333-333333333333/$ref"
+	if got != want {
+		t.Errorf("RelativeUri() = %q, want %q", got, want)
+	}
+}
+
+func TestClientRelativeUriWithoutTenantId(t *testing.T) {
+	client := NewClient(Version10, "11111111-1111-1111-1111-111111111111")
+
+	got, err := client.RelativeUri(Uri{Entity: "/$batch"})
+	if err != nil {
+		t.Fatalf("RelativeUri() returned error: %v", err)
+	}
+
+	if want := "/v1.0/$batch"; got != want {
+		t.Errorf("RelativeUri() = %q, want %q", got, want)
+	}
+}
@@ -7,9 +7,11 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/manicminer/hamilton/base"
 	"github.com/manicminer/hamilton/models"
+	"github.com/manicminer/hamilton/odata"
 )
 
 // GroupsClient performs operations on Groups.
@@ -24,42 +26,106 @@ func NewGroupsClient(tenantId string) *GroupsClient {
 	}
 }
 
-// List returns a list of Groups, optionally filtered using OData.
-func (c *GroupsClient) List(ctx context.Context, filter string) (*[]models.Group, int, error) {
-	params := url.Values{}
-	if filter != "" {
-		params.Add("$filter", filter)
+// List returns a list of Groups, optionally shaped using OData query options. Results are
+// paginated transparently by following @odata.nextLink until the full result set has been
+// retrieved.
+func (c *GroupsClient) List(ctx context.Context, query *base.ODataQuery) (*[]models.Group, int, error) {
+	groups, _, status, err := c.ListEx(ctx, query)
+	return groups, status, err
+}
+
+// ListEx behaves like List, additionally returning the parsed *odata.Error when Graph returned
+// a non-2xx response for one of the pages.
+func (c *GroupsClient) ListEx(ctx context.Context, query *base.ODataQuery) (*[]models.Group, *odata.Error, int, error) {
+	var groups []models.Group
+	status, oDataErr, err := c.ListPagesEx(ctx, query, func(page []models.Group) bool {
+		groups = append(groups, page...)
+		return true
+	})
+	if err != nil {
+		return nil, oDataErr, status, err
 	}
-	resp, status, _, err := c.BaseClient.Get(ctx, base.GetHttpRequestInput{
+	return &groups, nil, status, nil
+}
+
+// ListPages retrieves Groups a page at a time, optionally shaped using OData query options,
+// invoking the supplied callback for each page retrieved. Pagination follows @odata.nextLink
+// until the callback returns false or the full result set has been retrieved. Pagination
+// itself is handled by base.Client.GetPages, which every entity client shares.
+func (c *GroupsClient) ListPages(ctx context.Context, query *base.ODataQuery, callback func(page []models.Group) bool) (int, error) {
+	status, _, err := c.ListPagesEx(ctx, query, callback)
+	return status, err
+}
+
+// ListPagesEx behaves like ListPages, additionally returning the parsed *odata.Error when Graph
+// returned a non-2xx response for one of the pages.
+func (c *GroupsClient) ListPagesEx(ctx context.Context, query *base.ODataQuery, callback func(page []models.Group) bool) (int, *odata.Error, error) {
+	return c.BaseClient.GetPages(ctx, base.GetHttpRequestInput{
 		ValidStatusCodes: []int{http.StatusOK},
 		Uri: base.Uri{
 			Entity:      "/groups",
-			Params:      params,
 			HasTenantId: true,
 		},
+		ODataQuery: query,
+	}, func(page []byte) (string, bool, error) {
+		var data struct {
+			Groups   []models.Group `json:"value"`
+			NextLink string         `json:"@odata.nextLink"`
+		}
+		if err := json.Unmarshal(page, &data); err != nil {
+			return "", false, err
+		}
+		return data.NextLink, callback(data.Groups), nil
 	})
-	if err != nil {
-		return nil, status, err
-	}
-	defer resp.Body.Close()
-	respBody, _ := ioutil.ReadAll(resp.Body)
-	var data struct {
-		Groups []models.Group `json:"value"`
-	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, err
-	}
-	return &data.Groups, status, nil
+}
+
+// ListIter returns a list of Groups on a channel, optionally filtered using OData, following
+// @odata.nextLink transparently so that callers with large tenants can consume results as they
+// arrive instead of waiting for every page to be fetched. The returned error channel is closed
+// once iteration has finished, whether successfully or not.
+func (c *GroupsClient) ListIter(ctx context.Context, query *base.ODataQuery) (<-chan models.Group, <-chan error) {
+	groupChan := make(chan models.Group)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(groupChan)
+		defer close(errChan)
+
+		_, err := c.ListPages(ctx, query, func(page []models.Group) bool {
+			for _, group := range page {
+				select {
+				case groupChan <- group:
+				case <-ctx.Done():
+					return false
+				}
+			}
+			return true
+		})
+		if err != nil {
+			errChan <- err
+		}
+	}()
+
+	return groupChan, errChan
 }
 
 // Create creates a new Group.
 func (c *GroupsClient) Create(ctx context.Context, group models.Group) (*models.Group, int, error) {
+	newGroup, _, _, status, err := c.CreateEx(ctx, group)
+	return newGroup, status, err
+}
+
+// CreateEx creates a new Group, additionally returning the raw *http.Response and, if Graph
+// returned a non-2xx response, the parsed *odata.Error describing the failure. This is useful
+// for callers that need the Graph request-id or error code for diagnostics, or that want to
+// inspect a Retry-After header on a throttled response that exhausted automatic retries.
+func (c *GroupsClient) CreateEx(ctx context.Context, group models.Group) (*models.Group, *http.Response, *odata.Error, int, error) {
 	var status int
 	body, err := json.Marshal(group)
 	if err != nil {
-		return nil, status, err
+		return nil, nil, nil, status, err
 	}
-	resp, status, _, err := c.BaseClient.Post(ctx, base.PostHttpRequestInput{
+	resp, status, oDataErr, err := c.BaseClient.Post(ctx, base.PostHttpRequestInput{
 		Body:             body,
 		ValidStatusCodes: []int{http.StatusCreated},
 		Uri: base.Uri{
@@ -68,46 +134,62 @@ func (c *GroupsClient) Create(ctx context.Context, group models.Group) (*models.
 		},
 	})
 	if err != nil {
-		return nil, status, err
+		return nil, resp, oDataErr, status, err
 	}
 	defer resp.Body.Close()
 	respBody, _ := ioutil.ReadAll(resp.Body)
 	var newGroup models.Group
 	if err := json.Unmarshal(respBody, &newGroup); err != nil {
-		return nil, status, err
+		return nil, resp, oDataErr, status, err
 	}
-	return &newGroup, status, nil
+	return &newGroup, resp, oDataErr, status, nil
 }
 
-// Get retrieves a Group.
-func (c *GroupsClient) Get(ctx context.Context, id string) (*models.Group, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, base.GetHttpRequestInput{
+// Get retrieves a Group, optionally shaped using OData query options.
+func (c *GroupsClient) Get(ctx context.Context, id string, query *base.ODataQuery) (*models.Group, int, error) {
+	group, _, _, status, err := c.GetEx(ctx, id, query)
+	return group, status, err
+}
+
+// GetEx retrieves a Group, optionally shaped using OData query options, additionally
+// returning the raw *http.Response and, if Graph returned a non-2xx response, the parsed
+// *odata.Error describing the failure.
+func (c *GroupsClient) GetEx(ctx context.Context, id string, query *base.ODataQuery) (*models.Group, *http.Response, *odata.Error, int, error) {
+	resp, status, oDataErr, err := c.BaseClient.Get(ctx, base.GetHttpRequestInput{
 		ValidStatusCodes: []int{http.StatusOK},
 		Uri: base.Uri{
 			Entity:      fmt.Sprintf("/groups/%s", id),
 			HasTenantId: true,
 		},
+		ODataQuery: query,
 	})
 	if err != nil {
-		return nil, status, err
+		return nil, resp, oDataErr, status, err
 	}
 	defer resp.Body.Close()
 	respBody, _ := ioutil.ReadAll(resp.Body)
 	var group models.Group
 	if err := json.Unmarshal(respBody, &group); err != nil {
-		return nil, status, err
+		return nil, resp, oDataErr, status, err
 	}
-	return &group, status, nil
+	return &group, resp, oDataErr, status, nil
 }
 
 // Update amends an existing Group.
 func (c *GroupsClient) Update(ctx context.Context, group models.Group) (int, error) {
+	_, _, status, err := c.UpdateEx(ctx, group)
+	return status, err
+}
+
+// UpdateEx amends an existing Group, additionally returning the raw *http.Response and, if
+// Graph returned a non-2xx response, the parsed *odata.Error describing the failure.
+func (c *GroupsClient) UpdateEx(ctx context.Context, group models.Group) (*http.Response, *odata.Error, int, error) {
 	var status int
 	body, err := json.Marshal(group)
 	if err != nil {
-		return status, err
+		return nil, nil, status, err
 	}
-	_, status, _, err = c.BaseClient.Patch(ctx, base.PatchHttpRequestInput{
+	resp, status, oDataErr, err := c.BaseClient.Patch(ctx, base.PatchHttpRequestInput{
 		Body:             body,
 		ValidStatusCodes: []int{http.StatusNoContent},
 		Uri: base.Uri{
@@ -115,57 +197,118 @@ func (c *GroupsClient) Update(ctx context.Context, group models.Group) (int, err
 			HasTenantId: true,
 		},
 	})
-	if err != nil {
-		return status, err
-	}
-	return status, nil
+	return resp, oDataErr, status, err
 }
 
 // Delete removes a Group.
 func (c *GroupsClient) Delete(ctx context.Context, id string) (int, error) {
-	_, status, _, err := c.BaseClient.Delete(ctx, base.DeleteHttpRequestInput{
+	_, _, status, err := c.DeleteEx(ctx, id)
+	return status, err
+}
+
+// DeleteEx removes a Group, additionally returning the raw *http.Response and, if Graph
+// returned a non-2xx response, the parsed *odata.Error describing the failure.
+func (c *GroupsClient) DeleteEx(ctx context.Context, id string) (*http.Response, *odata.Error, int, error) {
+	resp, status, oDataErr, err := c.BaseClient.Delete(ctx, base.DeleteHttpRequestInput{
 		ValidStatusCodes: []int{http.StatusNoContent},
 		Uri: base.Uri{
 			Entity:      fmt.Sprintf("/groups/%s", id),
 			HasTenantId: true,
 		},
 	})
-	if err != nil {
-		return status, err
-	}
-	return status, nil
+	return resp, oDataErr, status, err
 }
 
-// ListMembers retrieves the members of the specified Group.
+// ListMembers retrieves the members of the specified Group, optionally shaped using OData
+// query options, following @odata.nextLink transparently so that the full membership is
+// returned even for large groups.
 // id is the object ID of the group.
-func (c *GroupsClient) ListMembers(ctx context.Context, id string) (*[]string, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, base.GetHttpRequestInput{
+func (c *GroupsClient) ListMembers(ctx context.Context, id string, query *base.ODataQuery) (*[]string, int, error) {
+	ret, _, status, err := c.ListMembersEx(ctx, id, query)
+	return ret, status, err
+}
+
+// ListMembersEx behaves like ListMembers, additionally returning the parsed *odata.Error when
+// Graph returned a non-2xx response for one of the pages.
+func (c *GroupsClient) ListMembersEx(ctx context.Context, id string, query *base.ODataQuery) (*[]string, *odata.Error, int, error) {
+	if query == nil {
+		query = &base.ODataQuery{Select: []string{"id"}}
+	}
+
+	var ret []string
+	status, oDataErr, err := c.BaseClient.GetPages(ctx, base.GetHttpRequestInput{
 		ValidStatusCodes: []int{http.StatusOK},
 		Uri: base.Uri{
 			Entity:      fmt.Sprintf("/groups/%s/members", id),
-			Params:      url.Values{"$select": []string{"id"}},
 			HasTenantId: true,
 		},
+		ODataQuery: query,
+	}, func(page []byte) (string, bool, error) {
+		var data struct {
+			Members []struct {
+				Type string `json:"@odata.type"`
+				Id   string `json:"id"`
+			} `json:"value"`
+			NextLink string `json:"@odata.nextLink"`
+		}
+		if err := json.Unmarshal(page, &data); err != nil {
+			return "", false, err
+		}
+		for _, v := range data.Members {
+			ret = append(ret, v.Id)
+		}
+		return data.NextLink, true, nil
 	})
 	if err != nil {
-		return nil, status, err
-	}
-	defer resp.Body.Close()
-	respBody, _ := ioutil.ReadAll(resp.Body)
-	var data struct {
-		Members []struct {
-			Type string `json:"@odata.type"`
-			Id   string `json:"id"`
-		} `json:"value"`
+		return nil, oDataErr, status, err
 	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, err
+	return &ret, nil, status, nil
+}
+
+// ListTransitiveMembers retrieves the transitive members of the specified Group, i.e. members
+// of the Group itself plus the members of any groups nested within it, optionally shaped
+// using OData query options and following @odata.nextLink transparently.
+// id is the object ID of the group.
+func (c *GroupsClient) ListTransitiveMembers(ctx context.Context, id string, query *base.ODataQuery) (*[]string, int, error) {
+	ret, _, status, err := c.ListTransitiveMembersEx(ctx, id, query)
+	return ret, status, err
+}
+
+// ListTransitiveMembersEx behaves like ListTransitiveMembers, additionally returning the parsed
+// *odata.Error when Graph returned a non-2xx response for one of the pages.
+func (c *GroupsClient) ListTransitiveMembersEx(ctx context.Context, id string, query *base.ODataQuery) (*[]string, *odata.Error, int, error) {
+	if query == nil {
+		query = &base.ODataQuery{Select: []string{"id"}}
 	}
-	ret := make([]string, len(data.Members))
-	for i, v := range data.Members {
-		ret[i] = v.Id
+
+	var ret []string
+	status, oDataErr, err := c.BaseClient.GetPages(ctx, base.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: base.Uri{
+			Entity:      fmt.Sprintf("/groups/%s/transitiveMembers", id),
+			HasTenantId: true,
+		},
+		ODataQuery: query,
+	}, func(page []byte) (string, bool, error) {
+		var data struct {
+			Members []struct {
+				Type string `json:"@odata.type"`
+				Id   string `json:"id"`
+			} `json:"value"`
+			NextLink string `json:"@odata.nextLink"`
+		}
+		if err := json.Unmarshal(page, &data); err != nil {
+			return "", false, err
+		}
+		for _, v := range data.Members {
+			ret = append(ret, v.Id)
+		}
+		return data.NextLink, true, nil
+	})
+	if err != nil {
+		return nil, oDataErr, status, err
 	}
-	return &ret, status, nil
+	return &ret, nil, status, nil
 }
 
 // GetMember retrieves a single member of the specified Group.
@@ -200,6 +343,14 @@ func (c *GroupsClient) GetMember(ctx context.Context, groupId, memberId string)
 // AddMembers adds a new member to a Group.
 // First populate the Members field of the Group using the AppendMember method of the model, then call this method.
 func (c *GroupsClient) AddMembers(ctx context.Context, group *models.Group) (int, error) {
+	_, _, status, err := c.AddMembersEx(ctx, group)
+	return status, err
+}
+
+// AddMembersEx behaves like AddMembers, additionally returning the raw *http.Response and, if
+// Graph returned a non-2xx response, the parsed *odata.Error describing the failure. If members
+// are added across multiple chunked requests, these describe the request that failed.
+func (c *GroupsClient) AddMembersEx(ctx context.Context, group *models.Group) (*http.Response, *odata.Error, int, error) {
 	var status int
 	// Patching group members support up to 20 members per request
 	var memberChunks [][]string
@@ -221,9 +372,9 @@ func (c *GroupsClient) AddMembers(ctx context.Context, group *models.Group) (int
 		}
 		body, err := json.Marshal(data)
 		if err != nil {
-			return status, err
+			return nil, nil, status, err
 		}
-		_, status, _, err = c.BaseClient.Patch(ctx, base.PatchHttpRequestInput{
+		resp, chunkStatus, oDataErr, err := c.BaseClient.Patch(ctx, base.PatchHttpRequestInput{
 			Body:             body,
 			ValidStatusCodes: []int{http.StatusNoContent},
 			Uri: base.Uri{
@@ -231,71 +382,165 @@ func (c *GroupsClient) AddMembers(ctx context.Context, group *models.Group) (int
 				HasTenantId: true,
 			},
 		})
+		status = chunkStatus
 		if err != nil {
-			return status, err
+			return resp, oDataErr, status, err
 		}
 	}
-	return status, nil
+	return nil, nil, status, nil
 }
 
-// RemoveMembers removes members from a Group.
+// RemoveMembers removes members from a Group. Removals are submitted in batches of up to
+// base.MaxBatchRequests via base.BatchClient, rather than a per-member existence check
+// followed by a per-member delete.
 // groupId is the object ID of the group.
 // memberIds is a *[]string containing object IDs of members to remove.
 func (c *GroupsClient) RemoveMembers(ctx context.Context, id string, memberIds *[]string) (int, error) {
+	_, _, status, err := c.RemoveMembersEx(ctx, id, memberIds)
+	return status, err
+}
+
+// RemoveMembersEx behaves like RemoveMembers, additionally returning the raw *http.Response for
+// the failing $batch call and, if Graph returned a non-2xx response either for the $batch call
+// itself or for an individual removal, the parsed *odata.Error describing the failure.
+func (c *GroupsClient) RemoveMembersEx(ctx context.Context, id string, memberIds *[]string) (*http.Response, *odata.Error, int, error) {
 	var status int
-	for _, memberId := range *memberIds {
-		// check for membership before attempting deletion
-		if _, status, err := c.GetMember(ctx, id, memberId); err != nil {
-			if status == http.StatusNotFound {
-				continue
-			}
-			return status, err
+	batchClient := base.NewBatchClient(c.BaseClient)
+
+	ids := *memberIds
+	for i := 0; i < len(ids); i += base.MaxBatchRequests {
+		end := i + base.MaxBatchRequests
+		if end > len(ids) {
+			end = len(ids)
 		}
-		var err error
-		_, status, _, err = c.BaseClient.Delete(ctx, base.DeleteHttpRequestInput{
-			ValidStatusCodes: []int{http.StatusNoContent},
-			Uri: base.Uri{
+		chunk := ids[i:end]
+
+		requests := make([]base.BatchRequestItem, len(chunk))
+		for j, memberId := range chunk {
+			requestUrl, err := c.BaseClient.RelativeUri(base.Uri{
 				Entity:      fmt.Sprintf("/groups/%s/members/%s/$ref", id, memberId),
 				HasTenantId: true,
-			},
-		})
+			})
+			if err != nil {
+				return nil, nil, status, err
+			}
+			requests[j] = base.BatchRequestItem{
+				Id:     strconv.Itoa(j),
+				Method: http.MethodDelete,
+				Url:    requestUrl,
+			}
+		}
+
+		responses, resp, oDataErr, batchStatus, err := batchClient.ExecuteEx(ctx, requests)
+		status = batchStatus
 		if err != nil {
-			return status, err
+			return resp, oDataErr, status, err
+		}
+
+		for j, memberId := range chunk {
+			response, ok := responses[strconv.Itoa(j)]
+			if !ok {
+				return resp, nil, status, fmt.Errorf("clients: no batch response for member %s", memberId)
+			}
+			// a 404 means the member was already absent from the group, which is not an error
+			if response.Status != http.StatusNoContent && response.Status != http.StatusNotFound {
+				return resp, odata.Parse(response.Body), response.Status, fmt.Errorf("clients: could not remove member %s: unexpected status %d", memberId, response.Status)
+			}
 		}
 	}
-	return status, nil
+	return nil, nil, status, nil
 }
 
-// ListOwners retrieves the owners of the specified Group.
+// ListTransitiveMemberOf retrieves the groups that the specified Group is nested within,
+// directly or transitively, optionally shaped using OData query options and following
+// @odata.nextLink transparently.
 // id is the object ID of the group.
-func (c *GroupsClient) ListOwners(ctx context.Context, id string) (*[]string, int, error) {
-	resp, status, _, err := c.BaseClient.Get(ctx, base.GetHttpRequestInput{
+func (c *GroupsClient) ListTransitiveMemberOf(ctx context.Context, id string, query *base.ODataQuery) (*[]string, int, error) {
+	ret, _, status, err := c.ListTransitiveMemberOfEx(ctx, id, query)
+	return ret, status, err
+}
+
+// ListTransitiveMemberOfEx behaves like ListTransitiveMemberOf, additionally returning the
+// parsed *odata.Error when Graph returned a non-2xx response for one of the pages.
+func (c *GroupsClient) ListTransitiveMemberOfEx(ctx context.Context, id string, query *base.ODataQuery) (*[]string, *odata.Error, int, error) {
+	if query == nil {
+		query = &base.ODataQuery{Select: []string{"id"}}
+	}
+
+	var ret []string
+	status, oDataErr, err := c.BaseClient.GetPages(ctx, base.GetHttpRequestInput{
 		ValidStatusCodes: []int{http.StatusOK},
 		Uri: base.Uri{
-			Entity:      fmt.Sprintf("/groups/%s/owners", id),
-			Params:      url.Values{"$select": []string{"id"}},
+			Entity:      fmt.Sprintf("/groups/%s/transitiveMemberOf", id),
 			HasTenantId: true,
 		},
+		ODataQuery: query,
+	}, func(page []byte) (string, bool, error) {
+		var data struct {
+			Groups []struct {
+				Type string `json:"@odata.type"`
+				Id   string `json:"id"`
+			} `json:"value"`
+			NextLink string `json:"@odata.nextLink"`
+		}
+		if err := json.Unmarshal(page, &data); err != nil {
+			return "", false, err
+		}
+		for _, v := range data.Groups {
+			ret = append(ret, v.Id)
+		}
+		return data.NextLink, true, nil
 	})
 	if err != nil {
-		return nil, status, err
-	}
-	defer resp.Body.Close()
-	respBody, _ := ioutil.ReadAll(resp.Body)
-	var data struct {
-		Owners []struct {
-			Type string `json:"@odata.type"`
-			Id   string `json:"id"`
-		} `json:"value"`
+		return nil, oDataErr, status, err
 	}
-	if err := json.Unmarshal(respBody, &data); err != nil {
-		return nil, status, err
+	return &ret, nil, status, nil
+}
+
+// ListOwners retrieves the owners of the specified Group, optionally shaped using OData
+// query options, following @odata.nextLink transparently so that the full ownership list is
+// returned even for large groups.
+// id is the object ID of the group.
+func (c *GroupsClient) ListOwners(ctx context.Context, id string, query *base.ODataQuery) (*[]string, int, error) {
+	ret, _, status, err := c.ListOwnersEx(ctx, id, query)
+	return ret, status, err
+}
+
+// ListOwnersEx behaves like ListOwners, additionally returning the parsed *odata.Error when
+// Graph returned a non-2xx response for one of the pages.
+func (c *GroupsClient) ListOwnersEx(ctx context.Context, id string, query *base.ODataQuery) (*[]string, *odata.Error, int, error) {
+	if query == nil {
+		query = &base.ODataQuery{Select: []string{"id"}}
 	}
-	ret := make([]string, len(data.Owners))
-	for i, v := range data.Owners {
-		ret[i] = v.Id
+
+	var ret []string
+	status, oDataErr, err := c.BaseClient.GetPages(ctx, base.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: base.Uri{
+			Entity:      fmt.Sprintf("/groups/%s/owners", id),
+			HasTenantId: true,
+		},
+		ODataQuery: query,
+	}, func(page []byte) (string, bool, error) {
+		var data struct {
+			Owners []struct {
+				Type string `json:"@odata.type"`
+				Id   string `json:"id"`
+			} `json:"value"`
+			NextLink string `json:"@odata.nextLink"`
+		}
+		if err := json.Unmarshal(page, &data); err != nil {
+			return "", false, err
+		}
+		for _, v := range data.Owners {
+			ret = append(ret, v.Id)
+		}
+		return data.NextLink, true, nil
+	})
+	if err != nil {
+		return nil, oDataErr, status, err
 	}
-	return &ret, status, nil
+	return &ret, nil, status, nil
 }
 
 // GetOwner retrieves a single owner for the specified Group.
@@ -327,59 +572,310 @@ func (c *GroupsClient) GetOwner(ctx context.Context, groupId, ownerId string) (*
 	return &data.Id, status, nil
 }
 
-// AddOwners adds a new owner to a Group.
+// AddOwners adds new owners to a Group. Additions are submitted in batches of up to
+// base.MaxBatchRequests via base.BatchClient.
 // First populate the Owners field of the Group using the AppendOwner method of the model, then call this method.
 func (c *GroupsClient) AddOwners(ctx context.Context, group *models.Group) (int, error) {
+	_, _, status, err := c.AddOwnersEx(ctx, group)
+	return status, err
+}
+
+// AddOwnersEx behaves like AddOwners, additionally returning the raw *http.Response for the
+// failing $batch call and, if Graph returned a non-2xx response either for the $batch call
+// itself or for an individual addition, the parsed *odata.Error describing the failure.
+func (c *GroupsClient) AddOwnersEx(ctx context.Context, group *models.Group) (*http.Response, *odata.Error, int, error) {
 	var status int
-	for _, owner := range *group.Owners {
-		data := struct {
-			Owner string `json:"@odata.id"`
-		}{
-			Owner: owner,
-		}
-		body, err := json.Marshal(data)
-		if err != nil {
-			return status, err
+	batchClient := base.NewBatchClient(c.BaseClient)
+
+	owners := *group.Owners
+	for i := 0; i < len(owners); i += base.MaxBatchRequests {
+		end := i + base.MaxBatchRequests
+		if end > len(owners) {
+			end = len(owners)
 		}
-		_, status, _, err = c.BaseClient.Post(ctx, base.PostHttpRequestInput{
-			Body:             body,
-			ValidStatusCodes: []int{http.StatusNoContent},
-			Uri: base.Uri{
+		chunk := owners[i:end]
+
+		requests := make([]base.BatchRequestItem, len(chunk))
+		for j, owner := range chunk {
+			body, err := json.Marshal(struct {
+				Owner string `json:"@odata.id"`
+			}{
+				Owner: owner,
+			})
+			if err != nil {
+				return nil, nil, status, err
+			}
+			requestUrl, err := c.BaseClient.RelativeUri(base.Uri{
 				Entity:      fmt.Sprintf("/groups/%s/owners/$ref", *group.ID),
 				HasTenantId: true,
-			},
-		})
+			})
+			if err != nil {
+				return nil, nil, status, err
+			}
+			requests[j] = base.BatchRequestItem{
+				Id:      strconv.Itoa(j),
+				Method:  http.MethodPost,
+				Url:     requestUrl,
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    body,
+			}
+		}
+
+		responses, resp, oDataErr, batchStatus, err := batchClient.ExecuteEx(ctx, requests)
+		status = batchStatus
 		if err != nil {
-			return status, err
+			return resp, oDataErr, status, err
+		}
+
+		for j, owner := range chunk {
+			response, ok := responses[strconv.Itoa(j)]
+			if !ok {
+				return resp, nil, status, fmt.Errorf("clients: no batch response for owner %s", owner)
+			}
+			if response.Status != http.StatusNoContent {
+				return resp, odata.Parse(response.Body), response.Status, fmt.Errorf("clients: could not add owner %s: unexpected status %d", owner, response.Status)
+			}
 		}
 	}
-	return status, nil
+	return nil, nil, status, nil
 }
 
-// RemoveOwners removes owners from a Group.
+// RemoveOwners removes owners from a Group. Removals are submitted in batches of up to
+// base.MaxBatchRequests via base.BatchClient, rather than a per-owner existence check
+// followed by a per-owner delete.
 // groupId is the object ID of the group.
 // ownerIds is a *[]string containing object IDs of owners to remove.
 func (c *GroupsClient) RemoveOwners(ctx context.Context, id string, ownerIds *[]string) (int, error) {
+	_, _, status, err := c.RemoveOwnersEx(ctx, id, ownerIds)
+	return status, err
+}
+
+// RemoveOwnersEx behaves like RemoveOwners, additionally returning the raw *http.Response for
+// the failing $batch call and, if Graph returned a non-2xx response either for the $batch call
+// itself or for an individual removal, the parsed *odata.Error describing the failure.
+func (c *GroupsClient) RemoveOwnersEx(ctx context.Context, id string, ownerIds *[]string) (*http.Response, *odata.Error, int, error) {
 	var status int
-	for _, ownerId := range *ownerIds {
-		// check for ownership before attempting deletion
-		if _, status, err := c.GetOwner(ctx, id, ownerId); err != nil {
-			if status == http.StatusNotFound {
-				continue
-			}
-			return status, err
+	batchClient := base.NewBatchClient(c.BaseClient)
+
+	ids := *ownerIds
+	for i := 0; i < len(ids); i += base.MaxBatchRequests {
+		end := i + base.MaxBatchRequests
+		if end > len(ids) {
+			end = len(ids)
 		}
-		var err error
-		_, status, _, err = c.BaseClient.Delete(ctx, base.DeleteHttpRequestInput{
-			ValidStatusCodes: []int{http.StatusNoContent},
-			Uri: base.Uri{
+		chunk := ids[i:end]
+
+		requests := make([]base.BatchRequestItem, len(chunk))
+		for j, ownerId := range chunk {
+			requestUrl, err := c.BaseClient.RelativeUri(base.Uri{
 				Entity:      fmt.Sprintf("/groups/%s/owners/%s/$ref", id, ownerId),
 				HasTenantId: true,
-			},
-		})
+			})
+			if err != nil {
+				return nil, nil, status, err
+			}
+			requests[j] = base.BatchRequestItem{
+				Id:     strconv.Itoa(j),
+				Method: http.MethodDelete,
+				Url:    requestUrl,
+			}
+		}
+
+		responses, resp, oDataErr, batchStatus, err := batchClient.ExecuteEx(ctx, requests)
+		status = batchStatus
 		if err != nil {
-			return status, err
+			return resp, oDataErr, status, err
+		}
+
+		for j, ownerId := range chunk {
+			response, ok := responses[strconv.Itoa(j)]
+			if !ok {
+				return resp, nil, status, fmt.Errorf("clients: no batch response for owner %s", ownerId)
+			}
+			// a 404 means the owner was already absent from the group, which is not an error
+			if response.Status != http.StatusNoContent && response.Status != http.StatusNotFound {
+				return resp, odata.Parse(response.Body), response.Status, fmt.Errorf("clients: could not remove owner %s: unexpected status %d", ownerId, response.Status)
+			}
+		}
+	}
+	return nil, nil, status, nil
+}
+
+// Delta performs a delta query for Groups, for incremental change tracking. If deltaLink is
+// empty, a full sync is performed: @odata.nextLink is followed transparently until the final
+// page, which carries an @odata.deltaLink that should be persisted by the caller. Passing that
+// deltaLink back in on a later call returns only the groups created, updated or deleted since
+// it was issued; deleted groups are reported by ID in removed rather than in groups.
+func (c *GroupsClient) Delta(ctx context.Context, deltaLink string, query *base.ODataQuery) (groups []models.Group, removed []string, nextDeltaLink string, err error) {
+	groups, removed, nextDeltaLink, _, err = c.DeltaEx(ctx, deltaLink, query)
+	return groups, removed, nextDeltaLink, err
+}
+
+// DeltaEx behaves like Delta, additionally returning the parsed *odata.Error when Graph returned
+// a non-2xx response for one of the pages.
+func (c *GroupsClient) DeltaEx(ctx context.Context, deltaLink string, query *base.ODataQuery) (groups []models.Group, removed []string, nextDeltaLink string, oDataErr *odata.Error, err error) {
+	_, oDataErr, err = c.BaseClient.GetPagesFrom(ctx, deltaLink, base.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: base.Uri{
+			Entity:      "/groups/delta",
+			HasTenantId: true,
+		},
+		ODataQuery: query,
+	}, func(page []byte) (string, bool, error) {
+		var data struct {
+			Groups    []models.Group `json:"value"`
+			NextLink  string         `json:"@odata.nextLink"`
+			DeltaLink string         `json:"@odata.deltaLink"`
+		}
+		if err := json.Unmarshal(page, &data); err != nil {
+			return "", false, err
+		}
+
+		for _, group := range data.Groups {
+			if group.Removed != nil {
+				if group.ID != nil {
+					removed = append(removed, *group.ID)
+				}
+				continue
+			}
+			groups = append(groups, group)
+		}
+
+		if data.NextLink == "" {
+			nextDeltaLink = data.DeltaLink
+			return "", false, nil
 		}
+		return data.NextLink, true, nil
+	})
+	if err != nil {
+		return nil, nil, "", oDataErr, err
+	}
+	return groups, removed, nextDeltaLink, nil, nil
+}
+
+// CheckMemberGroups checks whether the specified Group is a member, directly or transitively,
+// of each group in groupIds, and returns the subset that it belongs to.
+// id is the object ID of the group being checked.
+func (c *GroupsClient) CheckMemberGroups(ctx context.Context, id string, groupIds []string) (*[]string, int, error) {
+	values, _, _, status, err := c.CheckMemberGroupsEx(ctx, id, groupIds)
+	return values, status, err
+}
+
+// CheckMemberGroupsEx behaves like CheckMemberGroups, additionally returning the raw
+// *http.Response and, if Graph returned a non-2xx response, the parsed *odata.Error describing
+// the failure.
+func (c *GroupsClient) CheckMemberGroupsEx(ctx context.Context, id string, groupIds []string) (*[]string, *http.Response, *odata.Error, int, error) {
+	body, err := json.Marshal(struct {
+		GroupIds []string `json:"groupIds"`
+	}{
+		GroupIds: groupIds,
+	})
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+	resp, status, oDataErr, err := c.BaseClient.Post(ctx, base.PostHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: base.Uri{
+			Entity:      fmt.Sprintf("/groups/%s/checkMemberGroups", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, resp, oDataErr, status, err
+	}
+	defer resp.Body.Close()
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var data struct {
+		Value []string `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, resp, oDataErr, status, err
+	}
+	return &data.Value, resp, oDataErr, status, nil
+}
+
+// GetMemberGroups returns the object IDs of the groups that the specified Group is a member
+// of, directly or transitively. If securityEnabledOnly is true, only security-enabled groups
+// are returned.
+// id is the object ID of the group.
+func (c *GroupsClient) GetMemberGroups(ctx context.Context, id string, securityEnabledOnly bool) (*[]string, int, error) {
+	values, _, _, status, err := c.GetMemberGroupsEx(ctx, id, securityEnabledOnly)
+	return values, status, err
+}
+
+// GetMemberGroupsEx behaves like GetMemberGroups, additionally returning the raw *http.Response
+// and, if Graph returned a non-2xx response, the parsed *odata.Error describing the failure.
+func (c *GroupsClient) GetMemberGroupsEx(ctx context.Context, id string, securityEnabledOnly bool) (*[]string, *http.Response, *odata.Error, int, error) {
+	body, err := json.Marshal(struct {
+		SecurityEnabledOnly bool `json:"securityEnabledOnly"`
+	}{
+		SecurityEnabledOnly: securityEnabledOnly,
+	})
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+	resp, status, oDataErr, err := c.BaseClient.Post(ctx, base.PostHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: base.Uri{
+			Entity:      fmt.Sprintf("/groups/%s/getMemberGroups", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, resp, oDataErr, status, err
+	}
+	defer resp.Body.Close()
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var data struct {
+		Value []string `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, resp, oDataErr, status, err
+	}
+	return &data.Value, resp, oDataErr, status, nil
+}
+
+// GetMemberObjects returns the object IDs of all directory objects (groups and, where
+// applicable, other object types) that the specified Group is a member of, directly or
+// transitively. If securityEnabledOnly is true, only security-enabled groups are returned.
+// id is the object ID of the group.
+func (c *GroupsClient) GetMemberObjects(ctx context.Context, id string, securityEnabledOnly bool) (*[]string, int, error) {
+	values, _, _, status, err := c.GetMemberObjectsEx(ctx, id, securityEnabledOnly)
+	return values, status, err
+}
+
+// GetMemberObjectsEx behaves like GetMemberObjects, additionally returning the raw
+// *http.Response and, if Graph returned a non-2xx response, the parsed *odata.Error describing
+// the failure.
+func (c *GroupsClient) GetMemberObjectsEx(ctx context.Context, id string, securityEnabledOnly bool) (*[]string, *http.Response, *odata.Error, int, error) {
+	body, err := json.Marshal(struct {
+		SecurityEnabledOnly bool `json:"securityEnabledOnly"`
+	}{
+		SecurityEnabledOnly: securityEnabledOnly,
+	})
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+	resp, status, oDataErr, err := c.BaseClient.Post(ctx, base.PostHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: base.Uri{
+			Entity:      fmt.Sprintf("/groups/%s/getMemberObjects", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, resp, oDataErr, status, err
+	}
+	defer resp.Body.Close()
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var data struct {
+		Value []string `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, resp, oDataErr, status, err
 	}
-	return status, nil
+	return &data.Value, resp, oDataErr, status, nil
 }
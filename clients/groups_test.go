@@ -0,0 +1,250 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/manicminer/hamilton/base"
+)
+
+func TestGroupsClientDeltaSplitsRemovedGroups(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"value": [
+				{"id": "group-1", "displayName": "Group One"},
+				{"id": "group-2", "@removed": {"reason": "changed"}}
+			],
+			"@odata.deltaLink": "https://graph.microsoft.com/beta/groups/delta?$deltatoken=abc123"
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewGroupsClient("tenant-id")
+	client.BaseClient.Endpoint = server.URL
+
+	groups, removed, nextDeltaLink, err := client.Delta(context.Background(), "", nil)
+	if err != nil {
+		t.Fatalf("Delta() returned error: %v", err)
+	}
+
+	if len(groups) != 1 || groups[0].ID == nil || *groups[0].ID != "group-1" {
+		t.Errorf("groups = %#v, want a single Group with ID group-1", groups)
+	}
+	if len(removed) != 1 || removed[0] != "group-2" {
+		t.Errorf("removed = %#v, want [group-2]", removed)
+	}
+	if nextDeltaLink == "" {
+		t.Error("nextDeltaLink should be populated from the final page's @odata.deltaLink")
+	}
+}
+
+func TestGroupsClientRemoveMembersExBuildsTenantScopedBatchUrl(t *testing.T) {
+	const tenantId = "11111111-1111-1111-1111-111111111111"
+	const groupId = "22222222-2222-2222-2222-222222222222"
+	const memberId = "33333333-3333-3333-3333-333333333333"
+
+	var capturedUrl string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Requests []base.BatchRequestItem `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("could not decode batch request: %v", err)
+		}
+		if len(req.Requests) != 1 {
+			t.Fatalf("got %d batched sub-requests, want 1", len(req.Requests))
+		}
+		capturedUrl = req.Requests[0].Url
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(struct {
+			Responses []base.BatchResponseItem `json:"responses"`
+		}{
+			Responses: []base.BatchResponseItem{{Id: req.Requests[0].Id, Status: http.StatusNoContent}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewGroupsClient(tenantId)
+	client.BaseClient.Endpoint = server.URL
+
+	memberIds := []string{memberId}
+	if _, _, status, err := client.RemoveMembersEx(context.Background(), groupId, &memberIds); err != nil {
+		t.Fatalf("RemoveMembersEx() returned error: %v (status %d)", err, status)
+	}
+
+	want := fmt.Sprintf("/%s/%s/groups/%s/members/%s/$ref", base.VersionBeta, tenantId, groupId, memberId)
+	if capturedUrl != want {
+		t.Errorf("batch sub-request Url = %q, want %q", capturedUrl, want)
+	}
+}
+
+func TestGroupsClientListTransitiveMembers(t *testing.T) {
+	const groupId = "22222222-2222-2222-2222-222222222222"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wantPath := fmt.Sprintf("/beta/tenant-id/groups/%s/transitiveMembers", groupId)
+		if r.URL.Path != wantPath {
+			t.Errorf("request path = %s, want %s", r.URL.Path, wantPath)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"value":[{"@odata.type":"#microsoft.graph.user","id":"member-1"},{"@odata.type":"#microsoft.graph.user","id":"member-2"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewGroupsClient("tenant-id")
+	client.BaseClient.Endpoint = server.URL
+
+	members, status, err := client.ListTransitiveMembers(context.Background(), groupId, nil)
+	if err != nil {
+		t.Fatalf("ListTransitiveMembers() returned error: %v", err)
+	}
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+	want := []string{"member-1", "member-2"}
+	if members == nil || !reflect.DeepEqual(*members, want) {
+		t.Errorf("members = %#v, want %#v", members, want)
+	}
+}
+
+func TestGroupsClientListTransitiveMemberOf(t *testing.T) {
+	const groupId = "22222222-2222-2222-2222-222222222222"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wantPath := fmt.Sprintf("/beta/tenant-id/groups/%s/transitiveMemberOf", groupId)
+		if r.URL.Path != wantPath {
+			t.Errorf("request path = %s, want %s", r.URL.Path, wantPath)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"value":[{"@odata.type":"#microsoft.graph.group","id":"parent-group-1"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewGroupsClient("tenant-id")
+	client.BaseClient.Endpoint = server.URL
+
+	groups, status, err := client.ListTransitiveMemberOf(context.Background(), groupId, nil)
+	if err != nil {
+		t.Fatalf("ListTransitiveMemberOf() returned error: %v", err)
+	}
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+	want := []string{"parent-group-1"}
+	if groups == nil || !reflect.DeepEqual(*groups, want) {
+		t.Errorf("groups = %#v, want %#v", groups, want)
+	}
+}
+
+func TestGroupsClientCheckMemberGroups(t *testing.T) {
+	const groupId = "22222222-2222-2222-2222-222222222222"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			GroupIds []string `json:"groupIds"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("could not decode request body: %v", err)
+		}
+		want := []string{"group-a", "group-b"}
+		if !reflect.DeepEqual(body.GroupIds, want) {
+			t.Errorf("request groupIds = %#v, want %#v", body.GroupIds, want)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"value":["group-a"]}`))
+	}))
+	defer server.Close()
+
+	client := NewGroupsClient("tenant-id")
+	client.BaseClient.Endpoint = server.URL
+
+	result, status, err := client.CheckMemberGroups(context.Background(), groupId, []string{"group-a", "group-b"})
+	if err != nil {
+		t.Fatalf("CheckMemberGroups() returned error: %v", err)
+	}
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+	want := []string{"group-a"}
+	if result == nil || !reflect.DeepEqual(*result, want) {
+		t.Errorf("result = %#v, want %#v", result, want)
+	}
+}
+
+func TestGroupsClientGetMemberGroups(t *testing.T) {
+	const groupId = "22222222-2222-2222-2222-222222222222"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			SecurityEnabledOnly bool `json:"securityEnabledOnly"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("could not decode request body: %v", err)
+		}
+		if !body.SecurityEnabledOnly {
+			t.Errorf("securityEnabledOnly = %v, want true", body.SecurityEnabledOnly)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"value":["group-a","group-b"]}`))
+	}))
+	defer server.Close()
+
+	client := NewGroupsClient("tenant-id")
+	client.BaseClient.Endpoint = server.URL
+
+	result, status, err := client.GetMemberGroups(context.Background(), groupId, true)
+	if err != nil {
+		t.Fatalf("GetMemberGroups() returned error: %v", err)
+	}
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+	want := []string{"group-a", "group-b"}
+	if result == nil || !reflect.DeepEqual(*result, want) {
+		t.Errorf("result = %#v, want %#v", result, want)
+	}
+}
+
+func TestGroupsClientGetMemberObjects(t *testing.T) {
+	const groupId = "22222222-2222-2222-2222-222222222222"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			SecurityEnabledOnly bool `json:"securityEnabledOnly"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("could not decode request body: %v", err)
+		}
+		if body.SecurityEnabledOnly {
+			t.Errorf("securityEnabledOnly = %v, want false", body.SecurityEnabledOnly)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"value":["object-a"]}`))
+	}))
+	defer server.Close()
+
+	client := NewGroupsClient("tenant-id")
+	client.BaseClient.Endpoint = server.URL
+
+	result, status, err := client.GetMemberObjects(context.Background(), groupId, false)
+	if err != nil {
+		t.Fatalf("GetMemberObjects() returned error: %v", err)
+	}
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+	want := []string{"object-a"}
+	if result == nil || !reflect.DeepEqual(*result, want) {
+		t.Errorf("result = %#v, want %#v", result, want)
+	}
+}
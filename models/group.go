@@ -0,0 +1,35 @@
+package models
+
+// Group describes an Azure Active Directory Group object.
+type Group struct {
+	ID          *string   `json:"id,omitempty"`
+	DisplayName *string   `json:"displayName,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Members     *[]string `json:"-"`
+	Owners      *[]string `json:"-"`
+
+	// Removed is populated by delta queries to indicate that this Group was deleted since
+	// the last sync. It is nil for groups that were created or updated.
+	Removed *GroupRemoved `json:"@removed,omitempty"`
+}
+
+// GroupRemoved describes why a Group was removed, as reported by a delta query.
+type GroupRemoved struct {
+	Reason string `json:"reason"`
+}
+
+// AppendMember appends a new member object ID to the Group's Members field.
+func (g *Group) AppendMember(id string) {
+	if g.Members == nil {
+		g.Members = &[]string{}
+	}
+	*g.Members = append(*g.Members, id)
+}
+
+// AppendOwner appends a new owner object ID to the Group's Owners field.
+func (g *Group) AppendOwner(id string) {
+	if g.Owners == nil {
+		g.Owners = &[]string{}
+	}
+	*g.Owners = append(*g.Owners, id)
+}
@@ -0,0 +1,46 @@
+package odata
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Error is the structured error payload Microsoft Graph returns in the body of a non-2xx
+// response.
+type Error struct {
+	Code       string      `json:"code"`
+	Message    string      `json:"message"`
+	InnerError *InnerError `json:"innerError,omitempty"`
+}
+
+// InnerError carries additional diagnostic detail that Graph includes alongside an Error,
+// useful when reporting issues to Microsoft support.
+type InnerError struct {
+	Date            string `json:"date"`
+	RequestId       string `json:"request-id"`
+	ClientRequestId string `json:"client-request-id"`
+}
+
+// Error implements the error interface so an *Error can be used directly where an error is
+// expected.
+func (e *Error) Error() string {
+	if e == nil {
+		return ""
+	}
+	if e.InnerError != nil && e.InnerError.RequestId != "" {
+		return fmt.Sprintf("%s: %s (request-id: %s)", e.Code, e.Message, e.InnerError.RequestId)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Parse extracts the OData error from a Graph response body. It returns nil if body does not
+// contain a recognisable OData error payload.
+func Parse(body []byte) *Error {
+	var wrapper struct {
+		Error *Error `json:"error"`
+	}
+	if err := json.Unmarshal(body, &wrapper); err != nil {
+		return nil
+	}
+	return wrapper.Error
+}